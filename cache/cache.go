@@ -6,10 +6,24 @@ import (
 	"time"
 )
 
+// CacheItem is a single deprecated member persisted as part of a CacheEntry,
+// carrying the same metadata DeprecationResult does so a cached run (-c /
+// --cache) round-trips it instead of losing it.
+type CacheItem struct {
+	FullPath string `json:"fullPath"`
+
+	// Message is the full @deprecated Javadoc body text.
+	Message string `json:"message,omitempty"`
+	// Replacement is the suggested replacement API extracted from Message.
+	Replacement string `json:"replacement,omitempty"`
+	// ForRemoval mirrors @Deprecated(forRemoval=true) on the member.
+	ForRemoval bool `json:"forRemoval,omitempty"`
+}
+
 type CacheEntry struct {
-	Version     string    `json:"version"`
-	Items       []string  `json:"items"`
-	LastUpdated time.Time `json:"lastUpdated"`
+	Version     string      `json:"version"`
+	Items       []CacheItem `json:"items"`
+	LastUpdated time.Time   `json:"lastUpdated"`
 }
 
 type Cache struct {
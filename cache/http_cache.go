@@ -0,0 +1,369 @@
+package cache
+
+import (
+	"container/list"
+	"encoding/json"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// KeyIdentity identifies a single cached entry, for callers that want to
+// observe which entries get evicted (see HTTPCache.DrainEvicted).
+type KeyIdentity struct {
+	Partition string
+	Key       string
+}
+
+// HTTPCacheEntry is a single cached HTTP response, along with the validators
+// needed to make a conditional GET the next time it's requested.
+type HTTPCacheEntry struct {
+	Body         []byte
+	ETag         string
+	LastModified string
+}
+
+func (e HTTPCacheEntry) size() int64 {
+	return int64(len(e.Body)) + int64(len(e.ETag)) + int64(len(e.LastModified))
+}
+
+// Stats is a snapshot of HTTPCache usage, exposed for the CLI's -stats flag:
+// cumulative hit and miss counts, and the bytes currently held in the cache.
+type Stats struct {
+	Hits   int64
+	Misses int64
+	Bytes  int64
+}
+
+type partitionItem struct {
+	key   string
+	entry HTTPCacheEntry
+}
+
+type partition struct {
+	name       string
+	maxEntries int
+
+	mu    sync.Mutex
+	ll    *list.List
+	items map[string]*list.Element
+}
+
+// HTTPCache is a partitioned, memory-bounded LRU cache for Javadoc HTTP
+// fetches, modeled on Hugo's dynacache: each partition (one per Javadoc
+// source/version) evicts independently once it crosses its entry cap, and
+// whenever total cached bytes cross a soft memory ceiling (checked after each
+// Set and periodically by a background goroutine) each partition's
+// least-recently-used entry is pruned in turn until usage is back under it.
+type HTTPCache struct {
+	memLimit int64
+	curBytes int64 // atomic
+	hits     int64 // atomic
+	misses   int64 // atomic
+
+	mu         sync.Mutex
+	partitions map[string]*partition
+
+	evictedMu sync.Mutex
+	evicted   []KeyIdentity
+
+	stopOnce sync.Once
+	stop     chan struct{}
+}
+
+// DefaultMemLimit returns the soft memory ceiling the cache uses when
+// PAPERDEPRECATIONS_MEMLIMIT is unset: one quarter of total system memory,
+// falling back to a conservative 256MiB when that can't be determined (e.g.
+// non-Linux hosts, containers without /proc/meminfo).
+func DefaultMemLimit() int64 {
+	if v := os.Getenv("PAPERDEPRECATIONS_MEMLIMIT"); v != "" {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil && n > 0 {
+			return n
+		}
+	}
+	if total := systemMemory(); total > 0 {
+		return total / 4
+	}
+	return 256 * 1024 * 1024
+}
+
+// NewHTTPCache creates a cache with the given soft memory ceiling in bytes
+// and starts its background eviction goroutine. A non-positive memLimit
+// falls back to DefaultMemLimit().
+func NewHTTPCache(memLimit int64) *HTTPCache {
+	if memLimit <= 0 {
+		memLimit = DefaultMemLimit()
+	}
+	c := &HTTPCache{
+		memLimit:   memLimit,
+		partitions: make(map[string]*partition),
+		stop:       make(chan struct{}),
+	}
+	go c.evictLoop()
+	return c
+}
+
+// PartitionHandle scopes Get/Set calls to a single named partition while
+// byte accounting and eviction stay centralized on the parent HTTPCache.
+type PartitionHandle struct {
+	c *HTTPCache
+	p *partition
+}
+
+// Partition returns a handle to the named partition, creating it with the
+// given per-partition entry cap (<=0 means unbounded entry count, relying
+// solely on the global memory ceiling) on first use. A positive maxEntries
+// also updates the cap of a partition that already exists, evicting its
+// least-recently-used entries until it fits the new cap (e.g. one created
+// uncapped by LoadFromDisk before the real caller's first Partition call),
+// so a restored partition doesn't stay unbounded for the rest of the
+// process.
+func (c *HTTPCache) Partition(name string, maxEntries int) *PartitionHandle {
+	c.mu.Lock()
+	p, ok := c.partitions[name]
+	if !ok {
+		p = &partition{
+			name:       name,
+			maxEntries: maxEntries,
+			ll:         list.New(),
+			items:      make(map[string]*list.Element),
+		}
+		c.partitions[name] = p
+	} else if maxEntries > 0 {
+		p.mu.Lock()
+		p.maxEntries = maxEntries
+		for p.ll.Len() > p.maxEntries {
+			p.evictOldest(c)
+		}
+		p.mu.Unlock()
+	}
+	c.mu.Unlock()
+	return &PartitionHandle{c: c, p: p}
+}
+
+// Get returns the cached entry for key, marking it most-recently-used.
+func (h *PartitionHandle) Get(key string) (HTTPCacheEntry, bool) {
+	h.p.mu.Lock()
+	defer h.p.mu.Unlock()
+
+	el, ok := h.p.items[key]
+	if !ok {
+		atomic.AddInt64(&h.c.misses, 1)
+		return HTTPCacheEntry{}, false
+	}
+	h.p.ll.MoveToFront(el)
+	atomic.AddInt64(&h.c.hits, 1)
+	return el.Value.(*partitionItem).entry, true
+}
+
+// Set stores entry under key, evicting the partition's least-recently-used
+// entry if this insert pushes it past maxEntries, then checks the global
+// memory ceiling.
+func (h *PartitionHandle) Set(key string, entry HTTPCacheEntry) {
+	h.p.mu.Lock()
+	if el, ok := h.p.items[key]; ok {
+		old := el.Value.(*partitionItem).entry
+		atomic.AddInt64(&h.c.curBytes, entry.size()-old.size())
+		el.Value.(*partitionItem).entry = entry
+		h.p.ll.MoveToFront(el)
+	} else {
+		el := h.p.ll.PushFront(&partitionItem{key: key, entry: entry})
+		h.p.items[key] = el
+		atomic.AddInt64(&h.c.curBytes, entry.size())
+		if h.p.maxEntries > 0 && h.p.ll.Len() > h.p.maxEntries {
+			h.p.evictOldest(h.c)
+		}
+	}
+	h.p.mu.Unlock()
+
+	if atomic.LoadInt64(&h.c.curBytes) > h.c.memLimit {
+		h.c.evictUntilUnderLimit()
+	}
+}
+
+// evictOldest must be called with p.mu held.
+func (p *partition) evictOldest(c *HTTPCache) {
+	if el := p.ll.Back(); el != nil {
+		p.removeElement(el, c)
+	}
+}
+
+// removeElement must be called with p.mu held.
+func (p *partition) removeElement(el *list.Element, c *HTTPCache) {
+	item := el.Value.(*partitionItem)
+	p.ll.Remove(el)
+	delete(p.items, item.key)
+	atomic.AddInt64(&c.curBytes, -item.entry.size())
+	c.recordEviction(p.name, item.key)
+}
+
+func (c *HTTPCache) recordEviction(partitionName, key string) {
+	c.evictedMu.Lock()
+	c.evicted = append(c.evicted, KeyIdentity{Partition: partitionName, Key: key})
+	c.evictedMu.Unlock()
+}
+
+// DrainEvicted returns and clears the set of entries evicted since the last
+// call, so callers can observe cache pressure.
+func (c *HTTPCache) DrainEvicted() []KeyIdentity {
+	c.evictedMu.Lock()
+	defer c.evictedMu.Unlock()
+	drained := c.evicted
+	c.evicted = nil
+	return drained
+}
+
+// Stats returns a snapshot of the cumulative hit/miss counters and the
+// current cached byte total.
+func (c *HTTPCache) Stats() Stats {
+	return Stats{
+		Hits:   atomic.LoadInt64(&c.hits),
+		Misses: atomic.LoadInt64(&c.misses),
+		Bytes:  atomic.LoadInt64(&c.curBytes),
+	}
+}
+
+// Close stops the background eviction goroutine.
+func (c *HTTPCache) Close() {
+	c.stopOnce.Do(func() { close(c.stop) })
+}
+
+// persistedEntry is the on-disk representation of a single cached HTTP
+// response, used by SaveToDisk and LoadFromDisk to carry the cache across
+// runs so re-scraping thousands of member pages doesn't mean re-fetching
+// thousands of unchanged ones.
+type persistedEntry struct {
+	Partition    string `json:"partition"`
+	Key          string `json:"key"`
+	Body         []byte `json:"body"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+// SaveToDisk writes every entry currently held in the cache to path as JSON.
+func (c *HTTPCache) SaveToDisk(path string) error {
+	c.mu.Lock()
+	partitions := make([]*partition, 0, len(c.partitions))
+	for _, p := range c.partitions {
+		partitions = append(partitions, p)
+	}
+	c.mu.Unlock()
+
+	var entries []persistedEntry
+	for _, p := range partitions {
+		p.mu.Lock()
+		for el := p.ll.Front(); el != nil; el = el.Next() {
+			item := el.Value.(*partitionItem)
+			entries = append(entries, persistedEntry{
+				Partition:    p.name,
+				Key:          item.key,
+				Body:         item.entry.Body,
+				ETag:         item.entry.ETag,
+				LastModified: item.entry.LastModified,
+			})
+		}
+		p.mu.Unlock()
+	}
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// LoadFromDisk restores entries previously written by SaveToDisk into c. A
+// missing file is not an error: it just means there's nothing to warm the
+// cache with yet. Restored partitions start uncapped, but the cap from a
+// caller's first real Partition(name, maxEntries) call is applied
+// retroactively, so the partition doesn't stay unbounded for the rest of
+// the process.
+func (c *HTTPCache) LoadFromDisk(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	var entries []persistedEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		h := c.Partition(e.Partition, 0)
+		h.Set(e.Key, HTTPCacheEntry{Body: e.Body, ETag: e.ETag, LastModified: e.LastModified})
+	}
+	return nil
+}
+
+func (c *HTTPCache) evictLoop() {
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.evictUntilUnderLimit()
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+// evictUntilUnderLimit scans partitions round-robin, pruning each one's
+// least-recently-used entry in turn, until total cached bytes are back under
+// the configured soft ceiling. Recency is tracked per partition only, so the
+// entries pruned are not necessarily the least recently used across the
+// whole cache.
+func (c *HTTPCache) evictUntilUnderLimit() {
+	for atomic.LoadInt64(&c.curBytes) > c.memLimit {
+		c.mu.Lock()
+		partitions := make([]*partition, 0, len(c.partitions))
+		for _, p := range c.partitions {
+			partitions = append(partitions, p)
+		}
+		c.mu.Unlock()
+
+		evictedAny := false
+		for _, p := range partitions {
+			p.mu.Lock()
+			if el := p.ll.Back(); el != nil {
+				p.removeElement(el, c)
+				evictedAny = true
+			}
+			p.mu.Unlock()
+			if atomic.LoadInt64(&c.curBytes) <= c.memLimit {
+				break
+			}
+		}
+		if !evictedAny {
+			return
+		}
+	}
+}
+
+func systemMemory() int64 {
+	data, err := os.ReadFile("/proc/meminfo")
+	if err != nil {
+		return 0
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if !strings.HasPrefix(line, "MemTotal:") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			return 0
+		}
+		kb, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return 0
+		}
+		return kb * 1024
+	}
+	return 0
+}
@@ -0,0 +1,106 @@
+package cache
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// bigMemLimit keeps the global memory ceiling out of the way so these tests
+// exercise only the per-partition entry cap.
+const bigMemLimit = 1 << 30
+
+func TestPartitionEvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewHTTPCache(bigMemLimit)
+	defer c.Close()
+
+	h := c.Partition("papermc.io/1.21.3", 2)
+	h.Set("a", HTTPCacheEntry{Body: []byte("a")})
+	h.Set("b", HTTPCacheEntry{Body: []byte("b")})
+
+	// Touch "a" so "b" becomes the least-recently-used entry.
+	if _, ok := h.Get("a"); !ok {
+		t.Fatalf("Get(a) = false, want true")
+	}
+
+	// Pushes the partition over its cap of 2; "b" should be evicted, not "a".
+	h.Set("c", HTTPCacheEntry{Body: []byte("c")})
+
+	if _, ok := h.Get("b"); ok {
+		t.Fatalf("Get(b) = true, want false (should have been evicted)")
+	}
+	if _, ok := h.Get("a"); !ok {
+		t.Fatalf("Get(a) = false, want true (was recently used, shouldn't be evicted)")
+	}
+	if _, ok := h.Get("c"); !ok {
+		t.Fatalf("Get(c) = false, want true")
+	}
+
+	evicted := c.DrainEvicted()
+	if len(evicted) != 1 || evicted[0].Key != "b" {
+		t.Fatalf("DrainEvicted() = %+v, want a single eviction of key %q", evicted, "b")
+	}
+}
+
+func TestPartitionCapAppliedRetroactively(t *testing.T) {
+	c := NewHTTPCache(bigMemLimit)
+	defer c.Close()
+
+	// Simulate LoadFromDisk creating the partition uncapped before the real
+	// caller ever picks a cap.
+	uncapped := c.Partition("papermc.io/1.21.3", 0)
+	uncapped.Set("a", HTTPCacheEntry{Body: []byte("a")})
+	uncapped.Set("b", HTTPCacheEntry{Body: []byte("b")})
+	uncapped.Set("c", HTTPCacheEntry{Body: []byte("c")})
+
+	// The real caller's first Partition() call for this name should apply
+	// its cap retroactively, not leave the partition unbounded forever.
+	capped := c.Partition("papermc.io/1.21.3", 1)
+	capped.Set("d", HTTPCacheEntry{Body: []byte("d")})
+
+	if _, ok := capped.Get("a"); ok {
+		t.Fatalf("Get(a) = true, want false (cap should have evicted it)")
+	}
+	if _, ok := capped.Get("d"); !ok {
+		t.Fatalf("Get(d) = false, want true")
+	}
+}
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "http-cache.json")
+
+	c := NewHTTPCache(bigMemLimit)
+	h := c.Partition("papermc.io/1.21.3", 10)
+	h.Set("https://jd.papermc.io/paper/1.21.3/Foo.html", HTTPCacheEntry{
+		Body:         []byte("<html>Foo</html>"),
+		ETag:         `"abc123"`,
+		LastModified: "Wed, 21 Oct 2015 07:28:00 GMT",
+	})
+	if err := c.SaveToDisk(path); err != nil {
+		t.Fatalf("SaveToDisk() = %v, want nil", err)
+	}
+	c.Close()
+
+	restored := NewHTTPCache(bigMemLimit)
+	defer restored.Close()
+	if err := restored.LoadFromDisk(path); err != nil {
+		t.Fatalf("LoadFromDisk() = %v, want nil", err)
+	}
+
+	rh := restored.Partition("papermc.io/1.21.3", 10)
+	got, ok := rh.Get("https://jd.papermc.io/paper/1.21.3/Foo.html")
+	if !ok {
+		t.Fatalf("Get() after LoadFromDisk = false, want true")
+	}
+	if string(got.Body) != "<html>Foo</html>" || got.ETag != `"abc123"` || got.LastModified != "Wed, 21 Oct 2015 07:28:00 GMT" {
+		t.Fatalf("Get() after LoadFromDisk = %+v, want round-tripped entry", got)
+	}
+}
+
+func TestLoadFromDiskMissingFileIsNotAnError(t *testing.T) {
+	c := NewHTTPCache(bigMemLimit)
+	defer c.Close()
+
+	if err := c.LoadFromDisk(filepath.Join(t.TempDir(), "does-not-exist.json")); err != nil {
+		t.Fatalf("LoadFromDisk() on missing file = %v, want nil", err)
+	}
+}
@@ -3,8 +3,10 @@ package main
 import (
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -12,6 +14,8 @@ import (
 
 	"context"
 
+	"golang.org/x/time/rate"
+
 	"github.com/thelooter/JavaDocParser/cache"
 	"github.com/thelooter/JavaDocParser/parser"
 	"github.com/thelooter/JavaDocParser/templates"
@@ -29,8 +33,19 @@ func main() {
 	// HTML filename flags (-f, --file)
 	htmlFile := flag.String("f", defaultHtmlFile, "HTML output filename (short)")
 	htmlFileLong := flag.String("file", defaultHtmlFile, "HTML output filename (long)")
+	showStats := flag.Bool("stats", false, "Print HTTP cache hit/miss/byte stats to stderr when done")
+	sourceFlag := flag.String("source", "paper", "Comma-separated Javadoc sources to scrape: paper,spigot,bukkit,velocity,fabric")
+	qps := flag.Float64("qps", 5, "Maximum Javadoc HTTP requests per second")
 	flag.Parse()
 
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	var limiter *rate.Limiter
+	if *qps > 0 {
+		limiter = rate.NewLimiter(rate.Limit(*qps), 1)
+	}
+
 	// Combine short and long flags
 	isCached := *useCached || *useCache2
 	outDir := *outputDir
@@ -48,71 +63,229 @@ func main() {
 		return
 	}
 
-	config := NewJavadocConfig("https://jd.papermc.io/paper", "1.21.3", isCached)
+	httpCacheFile := filepath.Join(outDir, "http-cache.json")
+	httpCache := cache.NewHTTPCache(cache.DefaultMemLimit())
+	if err := httpCache.LoadFromDisk(httpCacheFile); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not load HTTP cache from %s: %v\n", httpCacheFile, err)
+	}
+	defer func() {
+		if err := httpCache.SaveToDisk(httpCacheFile); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not save HTTP cache to %s: %v\n", httpCacheFile, err)
+		}
+		httpCache.Close()
+	}()
+	if *showStats {
+		defer func() {
+			stats := httpCache.Stats()
+			evicted := httpCache.DrainEvicted()
+			fmt.Fprintf(os.Stderr, "HTTP cache: %d hits, %d misses, %d bytes resident, %d evicted this run\n", stats.Hits, stats.Misses, stats.Bytes, len(evicted))
+		}()
+	}
+
+	sources := strings.Split(*sourceFlag, ",")
+	for i := range sources {
+		sources[i] = strings.TrimSpace(sources[i])
+	}
+
+	htmlOut := filepath.Join(outDir, outFile)
+	if err := runSources(ctx, sources, "1.21.3", isCached, outDir, httpCache, limiter, htmlOut); err != nil {
+		fmt.Printf("Error generating report: %v\n", err)
+	}
+}
+
+// runSources scrapes every named Javadoc source (just "paper" in the common
+// case) through the pluggable parser.Source interface and merges the
+// results into one report grouped by source, then by version, then by
+// class. This is the CLI's single code path regardless of how many sources
+// were requested, so "paper" doesn't get a parallel ad-hoc implementation to
+// keep in sync with the others.
+//
+// The output file is written incrementally: the header goes out before any
+// source has been scraped, and each source's section is re-flushed to disk
+// as results arrive for it, so a partial report can be opened in a browser
+// while scraping continues.
+func runSources(ctx context.Context, sourceNames []string, version string, isCached bool, outDir string, httpCache *cache.HTTPCache, limiter *rate.Limiter, htmlFile string) error {
+	f, err := os.Create(htmlFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	lastUpdated := time.Now().Unix()
+	if err := templates.ReportMultiSourceHeader(sourceNames, lastUpdated).Render(ctx, f); err != nil {
+		return err
+	}
+	headerEnd, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+
+	flush := func(groups []templates.SourceGroup) error {
+		if _, err := f.Seek(headerEnd, io.SeekStart); err != nil {
+			return err
+		}
+		if err := f.Truncate(headerEnd); err != nil {
+			return err
+		}
+		for _, group := range groups {
+			if err := templates.ReportSourceSection(group).Render(ctx, f); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var sourceGroups []templates.SourceGroup
+	for _, name := range sourceNames {
+		src, err := parser.NewSource(name, version, parser.SourceOptions{
+			HTTPCache:   httpCache,
+			RateLimiter: limiter,
+		})
+		if err != nil {
+			return err
+		}
 
-	// Update file paths
-	cacheFile := filepath.Join(outDir, "deprecations.json")
-	compositeHtmlFile := filepath.Join(outDir, outFile)
+		cacheFile := filepath.Join(outDir, fmt.Sprintf("deprecations-%s.json", src.Name()))
+		onProgress := func(partial []parser.DeprecationResult) {
+			groups := append(append([]templates.SourceGroup{}, sourceGroups...), templates.SourceGroup{
+				Source:   src.Name(),
+				Versions: buildVersionGroups(partial),
+			})
+			if err := flush(groups); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: could not flush partial report for %s: %v\n", src.Name(), err)
+			}
+		}
 
-	if config.UseCachedData {
-		// Try to load and use loadCache first
+		results, err := fetchSourceResults(ctx, src, isCached, cacheFile, onProgress)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+
+		sourceGroups = append(sourceGroups, templates.SourceGroup{
+			Source:   src.Name(),
+			Versions: buildVersionGroups(results),
+		})
+		if err := flush(sourceGroups); err != nil {
+			return err
+		}
+	}
+
+	return templates.ReportFooter().Render(ctx, f)
+}
+
+// fetchSourceResults returns src's full deprecation result set. When
+// isCached is set and cacheFile holds a result set less than 24h old, it's
+// loaded from there instead of scraping src live. Otherwise it scrapes src
+// through parser.FetchAllDeprecations and writes the fresh results back to
+// cacheFile for next time.
+//
+// onProgress is called every flushEvery items while scraping live (and once
+// more with the final set before returning, or immediately with the loaded
+// set on a cache hit), so callers can flush a partial report without
+// waiting for every source to finish.
+func fetchSourceResults(ctx context.Context, src parser.Source, isCached bool, cacheFile string, onProgress func([]parser.DeprecationResult)) ([]parser.DeprecationResult, error) {
+	if isCached {
 		if loadCache, err := cache.LoadCache(cacheFile); err == nil && len(loadCache.Entries) > 0 {
-			// Check if loadCache is less than 24h old
 			if isCacheValid(loadCache.Entries[0].LastUpdated, 24*time.Hour) {
-				fmt.Println("Using cached data")
-				results := make([]parser.DeprecationResult, 0)
+				fmt.Printf("%s: using cached data\n", src.Name())
+				var results []parser.DeprecationResult
 				for _, entry := range loadCache.Entries {
 					for _, item := range entry.Items {
 						results = append(results, parser.DeprecationResult{
-							Item:    item,
-							Version: entry.Version,
+							Item:        item.FullPath,
+							Version:     entry.Version,
+							Message:     item.Message,
+							Replacement: item.Replacement,
+							ForRemoval:  item.ForRemoval,
 						})
 					}
 				}
-				if err := generateReport(results, config, &loadCache.Entries[0].LastUpdated, compositeHtmlFile); err != nil {
-					fmt.Printf("Error generating report: %v\n", err)
-				}
-				return
+				onProgress(results)
+				return results, nil
 			}
 		}
-		fmt.Println("Cache invalid or missing, fetching new data")
+		fmt.Printf("%s: cache invalid or missing, fetching new data\n", src.Name())
 	}
 
-	// Fetch main deprecated list
-	listHtml, err := config.FetchHTML("/deprecated-list.html")
+	const flushEvery = 25
+
+	resultsChan, total, err := parser.FetchAllDeprecations(ctx, src)
 	if err != nil {
-		fmt.Printf("Error fetching deprecated list: %v\n", err)
-		return
+		return nil, err
 	}
 
-	// Process all deprecated items
-	results := config.ParseDeprecations(listHtml)
-
-	// Print results
-	for _, result := range results {
-		if result.Error != nil {
-			fmt.Printf("Error processing %s: %v\n", result.Item, result.Error)
+	results := make([]parser.DeprecationResult, 0, total)
+	processed := 0
+	for dep := range resultsChan {
+		if dep.Error != nil {
+			fmt.Printf("%s: error processing %s: %v\n", src.Name(), dep.Item, dep.Error)
 		} else {
-			fmt.Printf("%s: deprecated since %s\n", result.Item, result.Version)
+			fmt.Printf("%s: %s deprecated since %s\n", src.Name(), dep.Item, dep.Version)
+		}
+
+		results = append(results, parser.DeprecationResult{
+			Item:        dep.Item,
+			Version:     dep.Version,
+			Error:       dep.Error,
+			Message:     dep.Message,
+			Replacement: dep.Replacement,
+			ForRemoval:  dep.ForRemoval,
+		})
+		processed++
+		fmt.Fprintf(os.Stderr, "{\"source\":%q,\"processed\":%d,\"total\":%d}\n", src.Name(), processed, total)
+
+		if processed%flushEvery == 0 {
+			onProgress(results)
 		}
 	}
+	onProgress(results)
 
-	// Generate report
-	if err := generateReport(results, config, nil, compositeHtmlFile); err != nil {
-		fmt.Printf("Error generating report: %v\n", err)
+	if err := saveReportCache(buildVersionGroups(results), cacheFile, time.Now().Unix()); err != nil {
+		log.Printf("%s: error saving cache: %v\n", src.Name(), err)
 	}
+
+	return results, nil
 }
 
-// Update function signature to accept cached time
-// generateReport generates an HTML report of deprecated items.
-// results: list of deprecation results to include in the report.
-// config: configuration for fetching and parsing Javadoc data.
-// cachedTime: optional time when the cache was last updated.
-// htmlFile: path to the output HTML file.
-func generateReport(results []parser.DeprecationResult, config *parser.JavadocConfig, cachedTime *time.Time, htmlFile string) error {
+// saveReportCache writes groups to the on-disk cache at cacheFile consulted
+// by -c/--cache, so the cached data includes the same Message/Replacement/
+// ForRemoval metadata a live run produces.
+func saveReportCache(groups []templates.VersionGroup, cacheFile string, lastUpdated int64) error {
+	reportCache, err := cache.LoadCache(cacheFile)
+	if err != nil {
+		return err
+	}
+
+	reportCache.Entries = nil
+	for _, group := range groups {
+		items := []cache.CacheItem{}
+		for _, classGroup := range group.Classes {
+			for _, item := range classGroup.Items {
+				items = append(items, cache.CacheItem{
+					FullPath:    item.FullPath,
+					Message:     item.Message,
+					Replacement: item.Replacement,
+					ForRemoval:  item.ForRemoval,
+				})
+			}
+		}
+		reportCache.Entries = append(reportCache.Entries, cache.CacheEntry{
+			Version:     group.Version,
+			Items:       items,
+			LastUpdated: time.Unix(lastUpdated, 0),
+		})
+	}
+
+	return reportCache.Save(cacheFile)
+}
+
+// buildVersionGroups groups deprecation results by version and then by
+// class, sorting both levels, so they can be handed straight to the report
+// templates. Results with an Error are bucketed into a synthetic "Unknown
+// Version" group instead of being dropped.
+func buildVersionGroups(results []parser.DeprecationResult) []templates.VersionGroup {
 	versionGroups := make(map[string]map[string][]templates.DeprecatedItem)
 	unknownVersionItems := []templates.DeprecatedItem{}
-	reportCache, err := cache.LoadCache("deprecations.json")
 
 	for _, result := range results {
 		if result.Error != nil {
@@ -131,12 +304,14 @@ func generateReport(results []parser.DeprecationResult, config *parser.JavadocCo
 		// Group by class within each version
 		classPath := getClassPath(result.Item)
 		versionGroups[result.Version][classPath] = append(versionGroups[result.Version][classPath], templates.DeprecatedItem{
-			FullPath: result.Item,
-			Name:     result.Item,
+			FullPath:    result.Item,
+			Name:        result.Item,
+			Message:     result.Message,
+			Replacement: result.Replacement,
+			ForRemoval:  result.ForRemoval,
 		})
 	}
 
-	// Process known versions
 	var groups []templates.VersionGroup
 	versions := make([]string, 0, len(versionGroups))
 	for version := range versionGroups {
@@ -178,54 +353,7 @@ func generateReport(results []parser.DeprecationResult, config *parser.JavadocCo
 		})
 	}
 
-	lastUpdated := time.Now().Unix()
-	if cachedTime != nil {
-		lastUpdated = cachedTime.Unix()
-	}
-
-	reportCache.Entries = nil
-
-	for _, group := range groups {
-		items := []string{}
-		for _, classGroup := range group.Classes {
-			for _, item := range classGroup.Items {
-				items = append(items, item.FullPath)
-			}
-		}
-		reportCache.Entries = append(reportCache.Entries, cache.CacheEntry{
-			Version:     group.Version,
-			Items:       items,
-			LastUpdated: time.Unix(lastUpdated, 0),
-		})
-	}
-
-	if err := reportCache.Save("deprecations.json"); err != nil {
-		log.Printf("Error saving cache: %v\n", err)
-		return err
-	}
-
-	report := templates.Report{
-		Groups:      groups,
-		LastUpdated: lastUpdated,
-	}
-	component := templates.ReportPage(report, config)
-	f, err := os.Create(htmlFile)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-
-	return component.Render(context.Background(), f)
-}
-
-func NewJavadocConfig(baseURL, version string, useCached bool) *parser.JavadocConfig {
-	// Trim trailing slashes from base URL
-	baseURL = strings.TrimRight(baseURL, "/")
-	return &parser.JavadocConfig{
-		BaseURL:       baseURL,
-		Version:       version,
-		UseCachedData: useCached,
-	}
+	return groups
 }
 
 func isCacheValid(lastUpdated time.Time, maxAge time.Duration) bool {
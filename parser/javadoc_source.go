@@ -0,0 +1,264 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/time/rate"
+
+	"github.com/thelooter/JavaDocParser/cache"
+)
+
+// legacyIndexItemRe matches entries in the deprecated-list.html index page
+// produced by the pre-JDK9 frames-style doclet, which Spigot and Bukkit's
+// Javadoc sites never moved off of.
+var legacyIndexItemRe = regexp.MustCompile(`<td class="colOne"><a href="([^"]+)"><code>([^<]+)</code></a></td>`)
+
+// legacyAsOfRe extracts the version out of the "As of 1.8, replaced by ..."
+// phrasing the legacy doclet uses in place of a since= annotation.
+var legacyAsOfRe = regexp.MustCompile(`(?i)as of\s+([\w.\-]+)`)
+
+// indexLister parses a deprecated-list.html index page into item references.
+type indexLister func(listHTML string) []ItemRef
+
+// annotationParser extracts deprecation metadata from a single item's detail
+// page.
+type annotationParser func(fullHTML, item string) deprecationAnnotation
+
+func modernIndexLister(listHTML string) []ItemRef {
+	matches := modernIndexItemRe.FindAllStringSubmatch(listHTML, -1)
+	refs := make([]ItemRef, 0, len(matches))
+	for _, match := range matches {
+		refs = append(refs, ItemRef{Item: match[2] + match[3], Link: match[1]})
+	}
+	return refs
+}
+
+func legacyIndexLister(listHTML string) []ItemRef {
+	matches := legacyIndexItemRe.FindAllStringSubmatch(listHTML, -1)
+	refs := make([]ItemRef, 0, len(matches))
+	for _, match := range matches {
+		refs = append(refs, ItemRef{Item: match[2], Link: match[1]})
+	}
+	return refs
+}
+
+func modernAnnotationParser(fullHTML, item string) deprecationAnnotation {
+	return extractDeprecationAnnotation(fullHTML, item)
+}
+
+// legacyAnnotationParser parses the pre-JDK9 doclet's member detail page,
+// which has no @Deprecated annotation span to read since()/forRemoval from.
+// Instead the whole deprecation notice is free text like "Deprecated. As of
+// 1.8, replaced by Foo.bar()", rendered next to a <a name="item"> anchor.
+func legacyAnnotationParser(fullHTML, item string) deprecationAnnotation {
+	itemName := item[strings.LastIndex(item, ".")+1:]
+
+	doc, err := html.Parse(strings.NewReader(fullHTML))
+	if err != nil {
+		return deprecationAnnotation{}
+	}
+
+	var findAnchor func(*html.Node) *html.Node
+	findAnchor = func(n *html.Node) *html.Node {
+		if n.Type == html.ElementNode && n.Data == "a" {
+			for _, attr := range n.Attr {
+				if (attr.Key == "name" || attr.Key == "id") && attr.Val == itemName {
+					return n
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if result := findAnchor(c); result != nil {
+				return result
+			}
+		}
+		return nil
+	}
+
+	anchor := findAnchor(doc)
+	if anchor == nil {
+		return deprecationAnnotation{}
+	}
+
+	var findDeprecationBlock func(*html.Node) *html.Node
+	findDeprecationBlock = func(n *html.Node) *html.Node {
+		if n.Type == html.ElementNode && n.Data == "div" {
+			for _, attr := range n.Attr {
+				if attr.Key == "class" && attr.Val == "block" {
+					return n
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if result := findDeprecationBlock(c); result != nil {
+				return result
+			}
+		}
+		return nil
+	}
+
+	// The deprecation block for a legacy member lives in the same member
+	// detail <li>/<dd> as its anchor, so search from the anchor's parent
+	// rather than the whole document to avoid matching an unrelated member.
+	scope := anchor.Parent
+	if scope == nil {
+		scope = anchor
+	}
+	block := findDeprecationBlock(scope)
+	if block == nil {
+		return deprecationAnnotation{}
+	}
+
+	message := strings.TrimSpace(nodeText(block))
+	version := ""
+	if m := legacyAsOfRe.FindStringSubmatch(message); m != nil {
+		version = m[1]
+	}
+
+	return deprecationAnnotation{
+		Since:       version,
+		Message:     message,
+		Replacement: extractReplacementFromMessage(message),
+	}
+}
+
+// javadocSource is a Source backed by a single Javadoc site, parameterized
+// over the two places sites commonly diverge: the deprecated-list index
+// format and the per-member annotation format.
+type javadocSource struct {
+	name            string
+	config          *JavadocConfig
+	listDeprecated  indexLister
+	parseAnnotation annotationParser
+}
+
+func (s *javadocSource) Name() string { return s.name }
+
+func (s *javadocSource) ListDeprecations(ctx context.Context) ([]ItemRef, error) {
+	listHTML, err := s.config.FetchHTML(ctx, "/deprecated-list.html")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", s.name, err)
+	}
+	return s.listDeprecated(listHTML), nil
+}
+
+func (s *javadocSource) FetchItem(ctx context.Context, ref ItemRef) (Deprecation, error) {
+	fetchedHTML, err := s.config.FetchHTML(ctx, ref.Link)
+	if err != nil {
+		return Deprecation{Item: ref.Item, Error: err}, nil
+	}
+
+	annotation := s.parseAnnotation(fetchedHTML, ref.Item)
+	version := annotation.Since
+	if version == "" {
+		version = "Unknown"
+	}
+
+	return Deprecation{
+		Item:        ref.Item,
+		Version:     version,
+		Message:     annotation.Message,
+		Replacement: annotation.Replacement,
+		ForRemoval:  annotation.ForRemoval,
+	}, nil
+}
+
+// SourceOptions bundles the cross-cutting knobs every Source constructor
+// accepts, so adding a new one (as happened with RateLimiter) doesn't mean
+// growing every constructor's positional parameter list again.
+//
+// There's deliberately no "use cached data" knob here: whether to skip
+// fetching entirely is a decision the caller makes before it ever
+// constructs a Source (see the CLI's per-source deprecations-<name>.json
+// cache), not something the HTTP fetch layer itself can act on.
+type SourceOptions struct {
+	// HTTPCache may be nil to disable HTTP response caching.
+	HTTPCache *cache.HTTPCache
+	// RateLimiter may be nil to disable rate limiting.
+	RateLimiter *rate.Limiter
+}
+
+func (o SourceOptions) config(baseURL, version string) *JavadocConfig {
+	return &JavadocConfig{
+		BaseURL:     baseURL,
+		Version:     version,
+		HTTPCache:   o.HTTPCache,
+		RateLimiter: o.RateLimiter,
+	}
+}
+
+// NewPaperSource scrapes the PaperMC Javadoc site for the given version.
+func NewPaperSource(version string, opts SourceOptions) Source {
+	return &javadocSource{
+		name:            "paper",
+		config:          opts.config("https://jd.papermc.io/paper", version),
+		listDeprecated:  modernIndexLister,
+		parseAnnotation: modernAnnotationParser,
+	}
+}
+
+// NewVelocitySource scrapes the Velocity Javadoc site for the given version.
+func NewVelocitySource(version string, opts SourceOptions) Source {
+	return &javadocSource{
+		name:            "velocity",
+		config:          opts.config("https://jd.papermc.io/velocity", version),
+		listDeprecated:  modernIndexLister,
+		parseAnnotation: modernAnnotationParser,
+	}
+}
+
+// NewFabricSource scrapes the Fabric Javadoc site for the given version.
+func NewFabricSource(version string, opts SourceOptions) Source {
+	return &javadocSource{
+		name:            "fabric",
+		config:          opts.config("https://maven.fabricmc.net/docs/yarn", version),
+		listDeprecated:  modernIndexLister,
+		parseAnnotation: modernAnnotationParser,
+	}
+}
+
+// NewSpigotSource scrapes the Spigot Javadoc site for the given version. It
+// still runs the pre-JDK9 doclet, so it's parsed with the legacy index and
+// annotation formats.
+func NewSpigotSource(version string, opts SourceOptions) Source {
+	return &javadocSource{
+		name:            "spigot",
+		config:          opts.config("https://hub.spigotmc.org/javadocs/spigot", version),
+		listDeprecated:  legacyIndexLister,
+		parseAnnotation: legacyAnnotationParser,
+	}
+}
+
+// NewBukkitSource scrapes the Bukkit Javadoc site for the given version,
+// using the same legacy doclet format as Spigot.
+func NewBukkitSource(version string, opts SourceOptions) Source {
+	return &javadocSource{
+		name:            "bukkit",
+		config:          opts.config("https://jd.bukkit.org", version),
+		listDeprecated:  legacyIndexLister,
+		parseAnnotation: legacyAnnotationParser,
+	}
+}
+
+// NewSource resolves a source name (as accepted by the CLI's -source flag)
+// to its Source implementation. Returns an error for unknown names.
+func NewSource(name, version string, opts SourceOptions) (Source, error) {
+	switch strings.ToLower(name) {
+	case "paper":
+		return NewPaperSource(version, opts), nil
+	case "velocity":
+		return NewVelocitySource(version, opts), nil
+	case "fabric":
+		return NewFabricSource(version, opts), nil
+	case "spigot":
+		return NewSpigotSource(version, opts), nil
+	case "bukkit":
+		return NewBukkitSource(version, opts), nil
+	default:
+		return nil, fmt.Errorf("unknown javadoc source %q", name)
+	}
+}
@@ -0,0 +1,91 @@
+package parser
+
+import "testing"
+
+// modernIndexFixture is a minimal excerpt of the JDK9+ HTML5 doclet's
+// deprecated-list.html, as rendered by PaperMC/Velocity/Fabric.
+const modernIndexFixture = `<html><body>
+<div class="col-summary-item-name"><a href="org/bukkit/Foo.html#bar()">org.bukkit.Foo.<wbr>bar()</a></div>
+</body></html>`
+
+// modernDetailFixture is a minimal excerpt of a JDK9+ doclet's member detail
+// page for the item in modernIndexFixture.
+const modernDetailFixture = `<html><body>
+<section id="bar()">
+<span class="annotations">@Deprecated(forRemoval=true, <a href="Foo.html#since()">since</a>="1.20")</span>
+<div class="deprecation-block">
+<div class="deprecation-comment">Use <code>Foo.baz()</code> instead.</div>
+</div>
+</section>
+</body></html>`
+
+func TestModernIndexLister(t *testing.T) {
+	refs := modernIndexLister(modernIndexFixture)
+	if len(refs) != 1 {
+		t.Fatalf("modernIndexLister() = %v, want 1 ref", refs)
+	}
+	if refs[0].Item != "org.bukkit.Foo.bar()" || refs[0].Link != "org/bukkit/Foo.html#bar()" {
+		t.Fatalf("modernIndexLister() = %+v, want {Item: org.bukkit.Foo.bar(), Link: org/bukkit/Foo.html#bar()}", refs[0])
+	}
+}
+
+func TestModernAnnotationParser(t *testing.T) {
+	got := modernAnnotationParser(modernDetailFixture, "org.bukkit.Foo.bar()")
+	want := deprecationAnnotation{
+		Since:       "1.20",
+		ForRemoval:  true,
+		Message:     "Use Foo.baz() instead.",
+		Replacement: "Foo.baz()",
+	}
+	if got != want {
+		t.Fatalf("modernAnnotationParser() = %+v, want %+v", got, want)
+	}
+}
+
+// legacyIndexFixture is a minimal excerpt of the pre-JDK9 frames-style
+// doclet's deprecated-list.html, as rendered by Spigot/Bukkit.
+const legacyIndexFixture = `<html><body>
+<table><tr>
+<td class="colOne"><a href="org/bukkit/Foo.html#bar()"><code>org.bukkit.Foo.bar()</code></a></td>
+</tr></table>
+</body></html>`
+
+// legacyDetailFixture is a minimal excerpt of a pre-JDK9 doclet's member
+// detail page for the item in legacyIndexFixture. Legacy doclets have no
+// @Deprecated annotation span to parse; the whole notice is free text next
+// to the member's anchor.
+const legacyDetailFixture = `<html><body>
+<li class="blockList">
+<a name="bar()"></a>
+<h4>bar</h4>
+<pre>public void bar()</pre>
+<div class="block">Deprecated. <i>As of 1.8, replaced by <code>Foo.baz()</code></i></div>
+</li>
+</body></html>`
+
+func TestLegacyIndexLister(t *testing.T) {
+	refs := legacyIndexLister(legacyIndexFixture)
+	if len(refs) != 1 {
+		t.Fatalf("legacyIndexLister() = %v, want 1 ref", refs)
+	}
+	if refs[0].Item != "org.bukkit.Foo.bar()" || refs[0].Link != "org/bukkit/Foo.html#bar()" {
+		t.Fatalf("legacyIndexLister() = %+v, want {Item: org.bukkit.Foo.bar(), Link: org/bukkit/Foo.html#bar()}", refs[0])
+	}
+}
+
+func TestLegacyAnnotationParser(t *testing.T) {
+	got := legacyAnnotationParser(legacyDetailFixture, "org.bukkit.Foo.bar()")
+	if got.Since != "1.8" {
+		t.Fatalf("legacyAnnotationParser() Since = %q, want %q", got.Since, "1.8")
+	}
+	const wantMessage = "Deprecated. As of 1.8, replaced by Foo.baz()"
+	if got.Message != wantMessage {
+		t.Fatalf("legacyAnnotationParser() Message = %q, want %q", got.Message, wantMessage)
+	}
+	// The legacy doclet's "replaced by" phrasing isn't the "Use X instead"
+	// convention extractReplacementFromMessage looks for, so no Replacement
+	// is expected here.
+	if got.Replacement != "" {
+		t.Fatalf("legacyAnnotationParser() Replacement = %q, want empty", got.Replacement)
+	}
+}
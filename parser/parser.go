@@ -1,27 +1,74 @@
 package parser
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
 	"regexp"
 	"strings"
-	"sync"
 
 	"golang.org/x/net/html"
+	"golang.org/x/time/rate"
+
+	"github.com/thelooter/JavaDocParser/cache"
 )
 
+// httpCachePartitionMaxEntries bounds the number of distinct pages kept per
+// Javadoc site/version partition, independent of the cache's global memory
+// ceiling.
+const httpCachePartitionMaxEntries = 5000
+
+// modernIndexItemRe matches entries in the deprecated-list.html index page
+// produced by the JDK 9+ HTML5 doclet, which PaperMC, Velocity and Fabric
+// all use.
+var modernIndexItemRe = regexp.MustCompile(`<div class="col-summary-item-name[^"]*"><a href="([^"]+)">([^<]*)(?:<wbr>)?([^<]+)</a></div>`)
+
 // DeprecationResult Result struct to hold both item info and potential error
 type DeprecationResult struct {
 	Item    string
 	Version string
 	Error   error
+
+	// Message is the full @deprecated Javadoc body text, e.g.
+	// "Use getFoo() instead." Empty when the member has no such text.
+	Message string
+	// Replacement is the suggested replacement API extracted from Message,
+	// when it follows the common "Use X instead" phrasing.
+	Replacement string
+	// ForRemoval mirrors @Deprecated(forRemoval=true) on the member.
+	ForRemoval bool
+}
+
+// deprecationAnnotation holds the values parsed out of the @Deprecated
+// annotation and its accompanying Javadoc text for a single member.
+type deprecationAnnotation struct {
+	Since       string
+	ForRemoval  bool
+	Message     string
+	Replacement string
 }
 
 type JavadocConfig struct {
-	BaseURL       string // e.g. "https://jd.papermc.io/paper"
-	Version       string // e.g. "1.21.3"
-	UseCachedData bool
+	BaseURL string // e.g. "https://jd.papermc.io/paper"
+	Version string // e.g. "1.21.3"
+
+	// HTTPCache, when set, routes FetchHTML through a bounded in-process
+	// cache keyed by URL with conditional-GET revalidation, so re-running
+	// against thousands of member pages doesn't mean thousands of fresh
+	// fetches. Nil disables caching and always fetches fresh.
+	HTTPCache *cache.HTTPCache
+
+	// RateLimiter, when set, throttles FetchHTML so a multi-worker run
+	// doesn't hammer the Javadoc site. Nil disables rate limiting.
+	RateLimiter *rate.Limiter
+}
+
+func (c *JavadocConfig) cachePartition() *cache.PartitionHandle {
+	if c.HTTPCache == nil {
+		return nil
+	}
+	return c.HTTPCache.Partition(c.BaseURL+"/"+c.Version, httpCachePartitionMaxEntries)
 }
 
 func (c *JavadocConfig) GetFullURL(path string) string {
@@ -33,11 +80,37 @@ func (c *JavadocConfig) GetFullURL(path string) string {
 	return url
 }
 
-func (c *JavadocConfig) FetchHTML(path string) (string, error) {
+func (c *JavadocConfig) FetchHTML(ctx context.Context, path string) (string, error) {
 	fullURL := c.GetFullURL(path)
 	fmt.Printf("Fetching URL: %s\n", fullURL)
 
-	resp, err := http.Get(fullURL)
+	partition := c.cachePartition()
+	var cached cache.HTTPCacheEntry
+	var haveCached bool
+	if partition != nil {
+		cached, haveCached = partition.Get(fullURL)
+	}
+
+	if c.RateLimiter != nil {
+		if err := c.RateLimiter.Wait(ctx); err != nil {
+			return "", err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fullURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("building request failed for %s: %v", fullURL, err)
+	}
+	if haveCached {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	resp, err := doWithRetry(ctx, req)
 	if err != nil {
 		return "", fmt.Errorf("fetch failed for %s: %v", fullURL, err)
 	}
@@ -48,98 +121,36 @@ func (c *JavadocConfig) FetchHTML(path string) (string, error) {
 		}
 	}(resp.Body)
 
+	if resp.StatusCode == http.StatusNotModified && haveCached {
+		fmt.Printf("Not modified, using cached copy of %s\n", fullURL)
+		return string(cached.Body), nil
+	}
+
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return "", fmt.Errorf("reading body failed for %s: %v", fullURL, err)
 	}
 
 	fmt.Printf("Fetched %d bytes from %s\n", len(body), fullURL)
-	return string(body), nil
-}
 
-func (c *JavadocConfig) processDeprecatedItem(link, item string) DeprecationResult {
-	fmt.Printf("Processing item: %s\n", item)
-	result := DeprecationResult{Item: item}
-
-	fetchedHTML, err := c.FetchHTML(link)
-	if err != nil {
-		result.Error = err
-		return result
-	}
-
-	version := extractDeprecatedSince(fetchedHTML, item)
-	if version == "" {
-		// Instead of treating this as an error, assign "Unknown" as the version
-		result.Version = "Unknown"
-		return result
+	if partition != nil {
+		partition.Set(fullURL, cache.HTTPCacheEntry{
+			Body:         body,
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+		})
 	}
 
-	result.Version = version
-	return result
-}
-
-func (c *JavadocConfig) ParseDeprecations(listHtml string) []DeprecationResult {
-	fmt.Printf("Parsing deprecation list (%d bytes)", len(listHtml))
-
-	itemRe := regexp.MustCompile(`<div class="col-summary-item-name[^"]*"><a href="([^"]+)">([^<]*)(?:<wbr>)?([^<]+)</a></div>`)
-	matches := itemRe.FindAllStringSubmatch(listHtml, -1)
-	fmt.Printf("Found %d deprecated items\n", len(matches))
-
-	//Iterate over matches and print out the item name and link
-	for _, match := range matches {
-		fullText := match[2] + match[3]
-		fmt.Printf("Item: %s, Link: %s\n", fullText, match[1])
-	}
-
-	// Create channels and WaitGroup
-	resultsChan := make(chan DeprecationResult, len(matches))
-	var wg sync.WaitGroup
-
-	// Number of worker goroutines
-	numWorkers := 10
-	itemsPerWorker := (len(matches) + numWorkers - 1) / numWorkers
-
-	for i := 0; i < numWorkers; i++ {
-		wg.Add(1)
-		go func(workerID int) {
-			defer wg.Done()
-
-			// Calculate start and end indices for this worker
-			start := workerID * itemsPerWorker
-			end := start + itemsPerWorker
-			if end > len(matches) {
-				end = len(matches)
-			}
-
-			// Process only this worker's portion of matches
-			for j := start; j < end; j++ {
-				match := matches[j]
-				fullText := match[2] + match[3]
-				workerItemCount := end - start
-				fmt.Printf("Worker %d processing item %d/%d: %s\n", workerID, j-start+1, workerItemCount, fullText)
-				result := c.processDeprecatedItem(match[1], fullText)
-				resultsChan <- result
-			}
-		}(i)
-	}
-
-	// Close results channel when all goroutines complete
-	go func() {
-		wg.Wait()
-		close(resultsChan)
-	}()
-
-	// Collect results
-	var results []DeprecationResult
-	for result := range resultsChan {
-		results = append(results, result)
-	}
-
-	return results
+	return string(body), nil
 }
 
-func extractDeprecatedSince(fullHTML, elementID string) string {
-	fmt.Printf("Extracting deprecated since value for element with ID: %q\n", elementID)
+// extractDeprecationAnnotation locates the <section id="elementID"> node for
+// a deprecated member and pulls out everything the @Deprecated annotation and
+// its accompanying Javadoc comment tell us: the since= version, the
+// forRemoval flag, and the free-text deprecation message (plus, where it
+// follows the common "Use X instead" phrasing, the suggested replacement).
+func extractDeprecationAnnotation(fullHTML, elementID string) deprecationAnnotation {
+	fmt.Printf("Extracting deprecation annotation for element with ID: %q\n", elementID)
 
 	// Only use the last part of the elementID
 	itemName := elementID[strings.LastIndex(elementID, ".")+1:]
@@ -147,18 +158,22 @@ func extractDeprecatedSince(fullHTML, elementID string) string {
 	doc, err := html.Parse(strings.NewReader(fullHTML))
 	if err != nil {
 		fmt.Printf("Error parsing HTML: %v\n", err)
-		return ""
+		return deprecationAnnotation{}
 	}
 	fmt.Println("Successfully parsed HTML document")
 
 	elementNode := findElementNodeByID(doc, itemName)
 	if elementNode == nil {
 		fmt.Printf("No element found with ID: %q\n", itemName)
-		return ""
+		return deprecationAnnotation{}
 	}
 	fmt.Printf("Found element with ID: %q\n", itemName)
 
-	return extractDeprecatedSinceFromNode(elementNode)
+	annotation := deprecationAnnotation{Since: extractDeprecatedSinceFromNode(elementNode)}
+	annotation.ForRemoval = extractForRemovalFromNode(elementNode)
+	annotation.Message = extractDeprecationMessageFromNode(elementNode)
+	annotation.Replacement = extractReplacementFromMessage(annotation.Message)
+	return annotation
 }
 
 func findElementNodeByID(n *html.Node, id string) *html.Node {
@@ -242,3 +257,95 @@ func extractDeprecatedSinceFromNode(n *html.Node) string {
 
 	return ""
 }
+
+// extractForRemovalFromNode reports whether the member's @Deprecated
+// annotation carries forRemoval=true, e.g. @Deprecated(since="1.20",
+// forRemoval=true).
+func extractForRemovalFromNode(n *html.Node) bool {
+	var findAnnotationsSpan func(*html.Node) *html.Node
+	findAnnotationsSpan = func(n *html.Node) *html.Node {
+		if n.Type == html.ElementNode && n.Data == "span" {
+			for _, attr := range n.Attr {
+				if attr.Key == "class" && attr.Val == "annotations" {
+					return n
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if result := findAnnotationsSpan(c); result != nil {
+				return result
+			}
+		}
+		return nil
+	}
+
+	annotationsSpan := findAnnotationsSpan(n)
+	if annotationsSpan == nil {
+		return false
+	}
+
+	return strings.Contains(nodeText(annotationsSpan), "forRemoval=true") ||
+		strings.Contains(nodeText(annotationsSpan), "forRemoval = true")
+}
+
+// extractDeprecationMessageFromNode pulls the free-text Javadoc body that
+// accompanies a @Deprecated annotation, which jd.papermc.io renders as a
+// <div class="deprecation-block"> wrapping a <div class="deprecation-comment">
+// sibling to the annotations span.
+func extractDeprecationMessageFromNode(n *html.Node) string {
+	var findDeprecationComment func(*html.Node) *html.Node
+	findDeprecationComment = func(n *html.Node) *html.Node {
+		if n.Type == html.ElementNode && (n.Data == "div") {
+			for _, attr := range n.Attr {
+				if attr.Key == "class" && strings.Contains(attr.Val, "deprecation-comment") {
+					return n
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if result := findDeprecationComment(c); result != nil {
+				return result
+			}
+		}
+		return nil
+	}
+
+	comment := findDeprecationComment(n)
+	if comment == nil {
+		return ""
+	}
+
+	return strings.TrimSpace(nodeText(comment))
+}
+
+// replacementRe matches the common Javadoc "Use X instead" deprecation
+// phrasing so the suggested replacement can be surfaced on its own.
+var replacementRe = regexp.MustCompile(`(?i)use\s+(.+?)\s+instead\.?`)
+
+// extractReplacementFromMessage pulls the suggested replacement API out of a
+// deprecation message, when it follows the "Use X instead" convention.
+// Returns "" when no such phrasing is present.
+func extractReplacementFromMessage(message string) string {
+	match := replacementRe.FindStringSubmatch(message)
+	if match == nil {
+		return ""
+	}
+	return strings.TrimSpace(match[1])
+}
+
+// nodeText concatenates the text content of n and all of its descendants,
+// collapsing runs of whitespace the way a browser would when rendering it.
+func nodeText(n *html.Node) string {
+	var b strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			b.WriteString(n.Data)
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return strings.Join(strings.Fields(b.String()), " ")
+}
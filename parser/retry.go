@@ -0,0 +1,108 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	maxFetchRetries  = 5
+	baseRetryBackoff = 250 * time.Millisecond
+	maxRetryBackoff  = 10 * time.Second
+)
+
+// isRetryableStatus reports whether an HTTP response status warrants a
+// retry: rate limiting and server-side errors, but not client errors like a
+// plain 404.
+func isRetryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || (code >= 500 && code < 600)
+}
+
+// parseRetryAfter parses a Retry-After header, which may be either a
+// delay in seconds or an HTTP-date, returning zero if absent or unparseable.
+func parseRetryAfter(h http.Header) time.Duration {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// retryDelay computes the backoff before the next attempt: the server's
+// Retry-After when given, otherwise exponential backoff with full jitter,
+// capped at maxRetryBackoff.
+func retryDelay(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return retryAfter
+	}
+	backoff := baseRetryBackoff * time.Duration(1<<uint(attempt))
+	if backoff > maxRetryBackoff {
+		backoff = maxRetryBackoff
+	}
+	return time.Duration(rand.Int63n(int64(backoff)))
+}
+
+// doWithRetry issues req (which must already carry ctx), retrying on
+// network errors and on 429/5xx responses with exponential backoff and
+// jitter, honoring a server-supplied Retry-After. It gives up after
+// maxFetchRetries retries or as soon as ctx is canceled, returning an error
+// in both cases (including when every attempt got a retryable status back)
+// rather than silently handing the caller a stale error response.
+func doWithRetry(ctx context.Context, req *http.Request) (*http.Response, error) {
+	var lastErr error
+	for attempt := 0; attempt <= maxFetchRetries; attempt++ {
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			lastErr = err
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			if attempt == maxFetchRetries {
+				break
+			}
+			if werr := waitOrCancel(ctx, retryDelay(attempt, 0)); werr != nil {
+				return nil, werr
+			}
+			continue
+		}
+
+		if !isRetryableStatus(resp.StatusCode) {
+			return resp, nil
+		}
+
+		if attempt == maxFetchRetries {
+			lastErr = fmt.Errorf("status %d", resp.StatusCode)
+			resp.Body.Close()
+			break
+		}
+
+		retryAfter := parseRetryAfter(resp.Header)
+		resp.Body.Close()
+		fmt.Printf("Retrying %s after status %d (attempt %d/%d)\n", req.URL, resp.StatusCode, attempt+1, maxFetchRetries)
+		if werr := waitOrCancel(ctx, retryDelay(attempt, retryAfter)); werr != nil {
+			return nil, werr
+		}
+	}
+	return nil, fmt.Errorf("giving up after %d attempts: %w", maxFetchRetries+1, lastErr)
+}
+
+func waitOrCancel(ctx context.Context, d time.Duration) error {
+	select {
+	case <-time.After(d):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,87 @@
+package parser
+
+import "context"
+
+// ItemRef identifies a single deprecated item discovered on a source's
+// deprecated-list index page, before its detail page has been fetched.
+type ItemRef struct {
+	// Item is the fully qualified member/class name, e.g. "org.bukkit.Foo.bar()".
+	Item string
+	// Link is the relative URL to the item's detail page, as it appears in
+	// the index page's href.
+	Link string
+}
+
+// Deprecation is the parsed result of fetching and inspecting a single
+// deprecated item's detail page, scoped to the Source that produced it.
+type Deprecation struct {
+	Item        string
+	Version     string
+	Message     string
+	Replacement string
+	ForRemoval  bool
+	Error       error
+}
+
+// Source abstracts over a single Javadoc site (PaperMC, Spigot, Bukkit,
+// Velocity, Fabric, ...) so the CLI can scrape and merge deprecations from
+// more than one at once. Each Javadoc site renders its deprecated-list index
+// page and its per-member annotations slightly differently, so it's up to
+// each Source implementation to know its own HTML.
+type Source interface {
+	// Name identifies the source in the merged report, e.g. "paper".
+	Name() string
+	// ListDeprecations fetches and parses the source's deprecated-list index
+	// page into item references, without fetching each item's detail page.
+	ListDeprecations(ctx context.Context) ([]ItemRef, error)
+	// FetchItem fetches and parses a single item's detail page.
+	FetchItem(ctx context.Context, ref ItemRef) (Deprecation, error)
+}
+
+// FetchAllDeprecations lists src's deprecated items and fetches every one of
+// them concurrently, returning a channel that yields each Deprecation as its
+// worker finishes along with the total item count. Items are fetched by at
+// most 10 worker goroutines, so any Source gets the same bounded concurrency
+// for free.
+func FetchAllDeprecations(ctx context.Context, src Source) (<-chan Deprecation, int, error) {
+	refs, err := src.ListDeprecations(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	resultsChan := make(chan Deprecation, len(refs))
+	work := make(chan ItemRef)
+
+	const numWorkers = 10
+	workers := numWorkers
+	if len(refs) < workers {
+		workers = len(refs)
+	}
+
+	done := make(chan struct{})
+	for i := 0; i < workers; i++ {
+		go func() {
+			for ref := range work {
+				result, err := src.FetchItem(ctx, ref)
+				if err != nil {
+					result = Deprecation{Item: ref.Item, Error: err}
+				}
+				resultsChan <- result
+			}
+			done <- struct{}{}
+		}()
+	}
+
+	go func() {
+		for _, ref := range refs {
+			work <- ref
+		}
+		close(work)
+		for i := 0; i < workers; i++ {
+			<-done
+		}
+		close(resultsChan)
+	}()
+
+	return resultsChan, len(refs), nil
+}
@@ -0,0 +1,129 @@
+package templates
+
+import (
+	"context"
+	"html/template"
+	"io"
+)
+
+// DeprecatedItem is a single deprecated member or class rendered in the report.
+type DeprecatedItem struct {
+	FullPath string
+	Name     string
+
+	// Replacement is the suggested API to use instead, when the Javadoc
+	// @deprecated text contains one (e.g. "Use Foo.bar() instead").
+	Replacement string
+	// Message is the full @deprecated body text as written by the author.
+	Message string
+	// ForRemoval mirrors @Deprecated(forRemoval=true) on the member.
+	ForRemoval bool
+}
+
+// ClassGroup collects the deprecated members declared on a single class.
+type ClassGroup struct {
+	ClassName string
+	Items     []DeprecatedItem
+}
+
+// VersionGroup collects the classes that gained deprecations in a given
+// version of a Javadoc source.
+type VersionGroup struct {
+	Version string
+	Classes []ClassGroup
+}
+
+// SourceGroup collects the version groups scraped from a single Javadoc
+// Source (e.g. "paper", "spigot"), for the merged multi-source report.
+type SourceGroup struct {
+	Source   string
+	Versions []VersionGroup
+}
+
+// Component is the minimal rendering contract returned by ReportMultiSourceHeader,
+// ReportSourceSection and ReportFooter, mirroring
+// the shape of a templ component so callers can keep writing
+// `component.Render(ctx, w)` regardless of how the page is assembled.
+type Component interface {
+	Render(ctx context.Context, w io.Writer) error
+}
+
+type componentFunc func(ctx context.Context, w io.Writer) error
+
+func (f componentFunc) Render(ctx context.Context, w io.Writer) error {
+	return f(ctx, w)
+}
+
+var (
+	multiSourceHeaderTmpl = template.Must(template.New("multiSourceHeader").Parse(reportMultiSourceHeaderHTML))
+	sourceSectionTmpl     = template.Must(template.New("sourceSection").Parse(reportSourceSectionHTML))
+	footerTmpl            = template.Must(template.New("footer").Parse(reportFooterHTML))
+)
+
+// ReportFooter renders the closing body/html markup. Callers write this once
+// all sections have been appended.
+func ReportFooter() Component {
+	return componentFunc(func(_ context.Context, w io.Writer) error {
+		return footerTmpl.Execute(w, nil)
+	})
+}
+
+// ReportMultiSourceHeader renders the document head and opening body markup
+// for a merged report spanning more than one Javadoc Source, e.g. when the
+// CLI is run with `-source paper,velocity`.
+func ReportMultiSourceHeader(sources []string, lastUpdated int64) Component {
+	return componentFunc(func(_ context.Context, w io.Writer) error {
+		return multiSourceHeaderTmpl.Execute(w, struct {
+			Sources     []string
+			LastUpdated int64
+		}{
+			Sources:     sources,
+			LastUpdated: lastUpdated,
+		})
+	})
+}
+
+// ReportSourceSection renders a single source's <section>, grouped by
+// source → version → class, for the merged multi-source report.
+func ReportSourceSection(group SourceGroup) Component {
+	return componentFunc(func(_ context.Context, w io.Writer) error {
+		return sourceSectionTmpl.Execute(w, group)
+	})
+}
+
+const reportFooterHTML = `</body>
+</html>
+`
+
+const reportMultiSourceHeaderHTML = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Deprecated APIs &mdash; {{range $i, $s := .Sources}}{{if $i}}, {{end}}{{$s}}{{end}}</title>
+</head>
+<body>
+<h1>Deprecated APIs</h1>
+`
+
+const reportSourceSectionHTML = `<section class="source">
+<h2>{{.Source}}</h2>
+{{range .Versions}}
+<section>
+<h3>{{.Version}}</h3>
+{{range .Classes}}
+<h4>{{.ClassName}}</h4>
+<ul>
+{{range .Items}}
+<li>
+<code>{{.Name}}</code>
+{{if .ForRemoval}}<strong>(for removal)</strong>{{end}}
+{{if .Message}}<p>{{.Message}}</p>{{end}}
+{{if .Replacement}}<p>Use <code>{{.Replacement}}</code> instead.</p>{{end}}
+</li>
+{{end}}
+</ul>
+{{end}}
+</section>
+{{end}}
+</section>
+`
@@ -5,17 +5,109 @@ import (
 	"strings"
 )
 
-// CompareVersions returns true if version a is newer than version b
+// Version is a parsed SemVer 2.0.0-style version string, split into its
+// numeric release components and its dot-separated prerelease identifiers.
+type Version struct {
+	Major, Minor, Patch int
+	// Prerelease holds the dot-separated identifiers after the first "-",
+	// e.g. ["pre2"] for "1.21.3-pre2" or ["R0", "1-SNAPSHOT"] for Paper's
+	// "1.21.4-R0.1-SNAPSHOT". Empty when the version has no prerelease.
+	Prerelease []string
+}
+
+// HasPrerelease reports whether v carries a prerelease suffix.
+func (v Version) HasPrerelease() bool {
+	return len(v.Prerelease) > 0
+}
+
+// ParseVersion parses a (possibly "v"-prefixed) dotted version string into
+// its release and prerelease components. Missing release components are
+// treated as zero, so "1.21" parses the same as "1.21.0".
+func ParseVersion(raw string) Version {
+	trimmed := strings.TrimPrefix(raw, "v")
+
+	release := trimmed
+	var prerelease string
+	if idx := strings.Index(trimmed, "-"); idx >= 0 {
+		release = trimmed[:idx]
+		prerelease = trimmed[idx+1:]
+	}
+
+	parts := strings.Split(release, ".")
+	component := func(i int) int {
+		if i >= len(parts) {
+			return 0
+		}
+		n, _ := strconv.Atoi(parts[i])
+		return n
+	}
+
+	v := Version{Major: component(0), Minor: component(1), Patch: component(2)}
+	if prerelease != "" {
+		v.Prerelease = strings.Split(prerelease, ".")
+	}
+	return v
+}
+
+// CompareVersions returns true if version a is newer than version b, using
+// SemVer 2.0.0 precedence rules: release components are compared
+// numerically with missing components treated as zero (so "1.21" ==
+// "1.21.0"), and when the release components tie, a version with no
+// prerelease is newer than one with a prerelease, otherwise prerelease
+// identifiers are compared per the SemVer spec (numeric identifiers compare
+// numerically, alphanumeric identifiers compare lexically, numeric always
+// sorts before alphanumeric, and a longer identifier list wins a tie).
 func CompareVersions(a, b string) bool {
-	aParts := strings.Split(strings.TrimPrefix(a, "v"), ".")
-	bParts := strings.Split(strings.TrimPrefix(b, "v"), ".")
-
-	for i := 0; i < len(aParts) && i < len(bParts); i++ {
-		aNum, _ := strconv.Atoi(aParts[i])
-		bNum, _ := strconv.Atoi(bParts[i])
-		if aNum != bNum {
-			return aNum > bNum
+	va := ParseVersion(a)
+	vb := ParseVersion(b)
+
+	if va.Major != vb.Major {
+		return va.Major > vb.Major
+	}
+	if va.Minor != vb.Minor {
+		return va.Minor > vb.Minor
+	}
+	if va.Patch != vb.Patch {
+		return va.Patch > vb.Patch
+	}
+
+	if va.HasPrerelease() != vb.HasPrerelease() {
+		// No prerelease outranks any prerelease once the release matches.
+		return !va.HasPrerelease()
+	}
+	if !va.HasPrerelease() {
+		return false // identical release, neither has a prerelease
+	}
+
+	return comparePrerelease(va.Prerelease, vb.Prerelease) > 0
+}
+
+// comparePrerelease compares two SemVer prerelease identifier lists,
+// returning >0 if a outranks b, <0 if b outranks a, and 0 if equal.
+func comparePrerelease(a, b []string) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		aNum, aIsNum := toInt(a[i])
+		bNum, bIsNum := toInt(b[i])
+
+		switch {
+		case aIsNum && bIsNum:
+			if aNum != bNum {
+				return aNum - bNum
+			}
+		case aIsNum && !bIsNum:
+			return -1 // numeric identifiers always sort before alphanumeric ones
+		case !aIsNum && bIsNum:
+			return 1
+		default:
+			if a[i] != b[i] {
+				return strings.Compare(a[i], b[i])
+			}
 		}
 	}
-	return len(aParts) > len(bParts)
+	return len(a) - len(b) // longer identifier list wins a tie
+}
+
+func toInt(s string) (int, bool) {
+	n, err := strconv.Atoi(s)
+	return n, err == nil
 }
@@ -0,0 +1,53 @@
+package templates
+
+import "testing"
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"1.21.1", "1.21.0", true},
+		{"1.21.0", "1.21.1", false},
+		{"1.21", "1.21.0", false},
+		{"1.21.0", "1.21", false},
+		{"1.21", "1.21.1", false},
+		{"1.21.3-pre2", "1.21.3", false},
+		{"1.21.3", "1.21.3-pre2", true},
+		{"1.21.3-pre2", "1.21.3-pre1", true},
+		{"1.21.3-pre1", "1.21.3-pre2", false},
+		{"1.20.6-rc1", "1.20.6-pre10", true},
+		{"1.20.6-pre10", "1.20.6-rc1", false},
+		{"1.21.4-R0.1-SNAPSHOT", "1.21.4-R0.1-SNAPSHOT", false},
+		{"1.21.4-R0.2-SNAPSHOT", "1.21.4-R0.1-SNAPSHOT", true},
+		{"1.21.3-pre10", "1.21.3-pre2", false}, // whole identifiers compare lexically: "pre10" < "pre2"
+		{"1.21.3-pre.1", "1.21.3-pre", true},
+	}
+
+	for _, tt := range tests {
+		if got := CompareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareVersions(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	v := ParseVersion("1.21.4-R0.1-SNAPSHOT")
+	if v.Major != 1 || v.Minor != 21 || v.Patch != 4 {
+		t.Fatalf("ParseVersion release = %d.%d.%d, want 1.21.4", v.Major, v.Minor, v.Patch)
+	}
+	wantPrerelease := []string{"R0", "1-SNAPSHOT"}
+	if len(v.Prerelease) != len(wantPrerelease) {
+		t.Fatalf("ParseVersion prerelease = %v, want %v", v.Prerelease, wantPrerelease)
+	}
+	for i, id := range wantPrerelease {
+		if v.Prerelease[i] != id {
+			t.Fatalf("ParseVersion prerelease = %v, want %v", v.Prerelease, wantPrerelease)
+		}
+	}
+
+	v2 := ParseVersion("1.21")
+	if v2.Major != 1 || v2.Minor != 21 || v2.Patch != 0 || v2.HasPrerelease() {
+		t.Fatalf("ParseVersion(%q) = %+v, want {1 21 0 []}", "1.21", v2)
+	}
+}